@@ -45,6 +45,8 @@ var uiCmd = &cobra.Command{
 func main() {
 	rootCmd.AddCommand(treeCmd)
 	rootCmd.AddCommand(uiCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(vaultCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
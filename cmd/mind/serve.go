@@ -0,0 +1,287 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/drewbolles/mind/internal/data"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagServeListen   string
+	flagServeReadOnly bool
+	flagServeToken    string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve vault projects over HTTP",
+	Long:  "Run an embedded HTTP server over the vault so the web UI (or remote collaborators) can browse and edit projects without reading files off disk directly.",
+	RunE:  runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&flagServeListen, "listen", ":4317", "address to listen on")
+	serveCmd.Flags().BoolVar(&flagServeReadOnly, "read-only", false, "reject writes (POST /projects/{id})")
+	serveCmd.Flags().StringVar(&flagServeToken, "token", "", "require this token in the X-Mind-Token header")
+}
+
+// server holds the state shared by HTTP handlers.
+type server struct {
+	dataDir  string
+	readOnly bool
+	token    string
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	dataDir, err := data.VaultDir()
+	if err != nil {
+		return err
+	}
+
+	s := &server{dataDir: dataDir, readOnly: flagServeReadOnly, token: flagServeToken}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects", s.handleProjects)
+	mux.HandleFunc("/projects/", s.handleProject)
+
+	fmt.Printf("serving %s on %s (read-only=%v)\n", dataDir, flagServeListen, s.readOnly)
+	return http.ListenAndServe(flagServeListen, s.withAuth(mux))
+}
+
+// withAuth rejects requests with a missing or mismatched X-Mind-Token header
+// when a token is configured. With no token configured, auth is a no-op.
+func (s *server) withAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" && r.Header.Get("X-Mind-Token") != s.token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleProjects serves GET /projects — the LoadProjectSummaries output.
+func (s *server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	summaries, err := data.LoadProjectSummaries(s.dataDir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(summaries)
+}
+
+// validProjectID matches the IDs data.SaveProject produces ({id}.json), and
+// is enforced on every project ID that reaches a filepath.Join in this file —
+// whether it comes from the URL path or a POST body — to keep one from
+// escaping s.dataDir via "..", "/", or similar.
+var validProjectID = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// handleProject serves everything under /projects/{id}, dispatching on the
+// path suffix: {id}, {id}/tree, or {id}.tar.gz.
+func (s *server) handleProject(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/projects/")
+
+	var id string
+	switch {
+	case strings.HasSuffix(rest, ".tar.gz"):
+		id = strings.TrimSuffix(rest, ".tar.gz")
+	case strings.HasSuffix(rest, "/tree"):
+		id = strings.TrimSuffix(rest, "/tree")
+	default:
+		id = rest
+	}
+
+	if !validProjectID.MatchString(id) {
+		http.Error(w, "invalid project id", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(rest, ".tar.gz"):
+		s.handleProjectTarball(w, r, id)
+	case strings.HasSuffix(rest, "/tree"):
+		s.handleProjectTree(w, r, id)
+	default:
+		s.handleProjectJSON(w, r, id)
+	}
+}
+
+func (s *server) loadProject(id string) (*data.Project, error) {
+	projects, err := data.LoadAllProjects(s.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	p := data.FindProject(projects, id)
+	if p == nil {
+		return nil, fmt.Errorf("project %q not found", id)
+	}
+	return p, nil
+}
+
+// handleProjectJSON serves GET (full Project JSON) and POST (SaveProject)
+// for a single project.
+func (s *server) handleProjectJSON(w http.ResponseWriter, r *http.Request, id string) {
+	switch r.Method {
+	case http.MethodGet:
+		p, err := s.loadProject(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(p)
+
+	case http.MethodPost:
+		if s.readOnly {
+			http.Error(w, "server is read-only", http.StatusForbidden)
+			return
+		}
+
+		var p data.Project
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			http.Error(w, fmt.Sprintf("decoding project: %v", err), http.StatusBadRequest)
+			return
+		}
+		if p.ID == "" {
+			p.ID = id
+		}
+		if !validProjectID.MatchString(p.ID) {
+			http.Error(w, "invalid project id", http.StatusBadRequest)
+			return
+		}
+
+		if err := data.SaveProject(s.dataDir, &p); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleProjectTree serves GET /projects/{id}/tree, rendering the same
+// ASCII tree as the CLI. An Accept: text/html request gets the ANSI output
+// wrapped in <span> tags instead.
+func (s *server) handleProjectTree(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	p, err := s.loadProject(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	var buf bytes.Buffer
+	RenderProject(&buf, p)
+
+	if strings.Contains(r.Header.Get("Accept"), "text/html") {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, "<pre>%s</pre>", ansiToHTML(buf.String()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// handleProjectTarball serves GET /projects/{id}.tar.gz — a tarball of
+// {id}.json plus any attachments/{id}/ directory in the vault.
+func (s *server) handleProjectTarball(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	jsonPath := filepath.Join(s.dataDir, id+".json")
+	body, err := os.ReadFile(jsonPath)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("project %q not found", id), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.tar.gz"`, id))
+
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	if err := tw.WriteHeader(&tar.Header{Name: id + ".json", Mode: 0o644, Size: int64(len(body))}); err != nil {
+		return
+	}
+	if _, err := tw.Write(body); err != nil {
+		return
+	}
+
+	attachDir := filepath.Join(s.dataDir, "attachments", id)
+	filepath.WalkDir(attachDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dataDir, path)
+		if err != nil {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: rel, Mode: 0o644, Size: info.Size()}); err != nil {
+			return nil
+		}
+		io.Copy(tw, f)
+		return nil
+	})
+}
+
+// ansiToHTML escapes s for HTML and rewrites the ANSI codes from style.go
+// into <span> tags. Escaping first is safe: none of the ANSI escape
+// sequences contain the characters html.EscapeString rewrites.
+func ansiToHTML(s string) string {
+	replacer := strings.NewReplacer(
+		reset, "</span>",
+		bold, `<span style="font-weight:bold">`,
+		dim, `<span style="opacity:0.6">`,
+		red, `<span style="color:red">`,
+		green, `<span style="color:green">`,
+		yellow, `<span style="color:#b58900">`,
+		blue, `<span style="color:#268bd2">`,
+		magenta, `<span style="color:magenta">`,
+		cyan, `<span style="color:cyan">`,
+		white, `<span style="color:inherit">`,
+		gray, `<span style="color:gray">`,
+	)
+	return replacer.Replace(html.EscapeString(s))
+}
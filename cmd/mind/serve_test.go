@@ -0,0 +1,101 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T, readOnly bool, token string) *server {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "p1.json"), []byte(`{"id":"p1","name":"P1","nodes":[]}`), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return &server{dataDir: dir, readOnly: readOnly, token: token}
+}
+
+func TestHandleProjects(t *testing.T) {
+	s := newTestServer(t, false, "")
+
+	w := httptest.NewRecorder()
+	s.handleProjects(w, httptest.NewRequest(http.MethodGet, "/projects", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"p1"`) {
+		t.Errorf("expected response to list p1, got: %s", w.Body.String())
+	}
+}
+
+func TestHandleProjectJSON(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		wantStatus int
+	}{
+		{"existing project", "p1", http.StatusOK},
+		{"missing project", "does-not-exist", http.StatusNotFound},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := newTestServer(t, false, "")
+			w := httptest.NewRecorder()
+			s.handleProjectJSON(w, httptest.NewRequest(http.MethodGet, "/projects/"+c.id, nil), c.id)
+
+			if w.Code != c.wantStatus {
+				t.Errorf("expected status %d, got %d", c.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestHandleProjectJSONPostRejectsReadOnly(t *testing.T) {
+	s := newTestServer(t, true, "")
+
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"id":"p1","name":"P1 Updated","nodes":[]}`)
+	s.handleProjectJSON(w, httptest.NewRequest(http.MethodPost, "/projects/p1", body), "p1")
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected a read-only server to reject POST with 403, got %d", w.Code)
+	}
+}
+
+func TestHandleProjectJSONPostRejectsInvalidID(t *testing.T) {
+	s := newTestServer(t, false, "")
+
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"id":"../../../../tmp/pwned","name":"x","nodes":[]}`)
+	s.handleProjectJSON(w, httptest.NewRequest(http.MethodPost, "/projects/p1", body), "p1")
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected a path-traversal project id to be rejected with 400, got %d", w.Code)
+	}
+}
+
+func TestWithAuthRequiresMatchingToken(t *testing.T) {
+	s := newTestServer(t, false, "secret")
+	handler := s.withAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/projects", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected a missing token to be rejected with 401, got %d", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+	req.Header.Set("X-Mind-Token", "secret")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected a matching token to pass through, got %d", w.Code)
+	}
+}
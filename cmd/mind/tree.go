@@ -19,32 +19,42 @@ func truncate(s string, max int) string {
 	return s[:max-3] + "..."
 }
 
-// runTree loads projects and prints trees.
+// runTree prints a tree for a single project, or a fast overview of every
+// project when no projectID is given.
 func runTree(projectID string) error {
 	dataDir, err := data.VaultDir()
 	if err != nil {
 		return err
 	}
 
+	if projectID == "" {
+		return runTreeOverview(dataDir)
+	}
+
 	projects, err := data.LoadAllProjects(dataDir)
 	if err != nil {
 		return err
 	}
 
-	if projectID != "" {
-		p := data.FindProject(projects, projectID)
-		if p == nil {
-			return fmt.Errorf("project %q not found", projectID)
-		}
-		RenderProject(os.Stdout, p)
-		return nil
+	p := data.FindProject(projects, projectID)
+	if p == nil {
+		return fmt.Errorf("project %q not found", projectID)
+	}
+	RenderProject(os.Stdout, p)
+	return nil
+}
+
+// runTreeOverview prints one line per project using the index-backed
+// summaries, so listing every project is O(changed files) rather than
+// O(all files). Use `mind tree <project>` for the full tree.
+func runTreeOverview(dataDir string) error {
+	summaries, err := data.LoadProjectSummaries(dataDir)
+	if err != nil {
+		return err
 	}
 
-	for i, p := range projects {
-		if i > 0 {
-			fmt.Println()
-		}
-		RenderProject(os.Stdout, p)
+	for _, s := range summaries {
+		fmt.Fprintf(os.Stdout, "%s%s%s  %s(%s)%s\n", bold, s.Name, reset, dim, s.ID, reset)
 	}
 
 	return nil
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -12,8 +14,9 @@ import (
 )
 
 var (
-	flagGitHub bool
-	flagGitLab bool
+	flagGitHub        bool
+	flagGitLab        bool
+	flagRequireSigned bool
 )
 
 var vaultCmd = &cobra.Command{
@@ -40,92 +43,95 @@ var vaultStatusCmd = &cobra.Command{
 	RunE:  runVaultStatus,
 }
 
+var vaultPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Aggregate project JSON from configured sources (~/.mind/sources.json)",
+	Long:  "Fetch every source listed in ~/.mind/sources.json into the vault under imported/<source-id>/, recording a single octopus-merge commit that keeps each source's full history reachable.",
+	RunE:  runVaultPull,
+}
+
+var vaultVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Report the GPG signature state of every commit in the vault",
+	RunE:  runVaultVerify,
+}
+
+var vaultMergeCmd = &cobra.Command{
+	Use:   "merge <base> <ours> <theirs> <out>",
+	Short: "Three-way merge project JSON",
+	Long:  "Merge project JSON node-by-node and edge-by-edge instead of leaving <<<<<<< markers. Register it as a git merge driver with:\n\n  git config merge.mind.driver 'mind vault merge %O %A %B %A'\n\nand a .gitattributes entry: *.json merge=mind",
+	Args:  cobra.ExactArgs(4),
+	RunE:  runVaultMerge,
+}
+
 func init() {
 	vaultInitCmd.Flags().BoolVar(&flagGitHub, "github", false, "Create private GitHub repo via gh CLI")
 	vaultInitCmd.Flags().BoolVar(&flagGitLab, "gitlab", false, "Create private GitLab repo via glab CLI")
+	vaultSyncCmd.Flags().BoolVar(&flagRequireSigned, "require-signed", false, "Refuse to fast-forward across unsigned commits")
 
 	vaultCmd.AddCommand(vaultInitCmd)
 	vaultCmd.AddCommand(vaultSyncCmd)
 	vaultCmd.AddCommand(vaultStatusCmd)
+	vaultCmd.AddCommand(vaultPullCmd)
+	vaultCmd.AddCommand(vaultVerifyCmd)
+	vaultCmd.AddCommand(vaultMergeCmd)
 }
 
 func vaultDir() (string, error) {
 	return data.VaultDir()
 }
 
-func git(dir string, args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
-	cmd.Dir = dir
-	out, err := cmd.CombinedOutput()
-	return strings.TrimSpace(string(out)), err
-}
-
-func gitQuiet(dir string, args ...string) error {
-	_, err := git(dir, args...)
-	return err
-}
-
-func isGitRepo(dir string) bool {
-	err := gitQuiet(dir, "rev-parse", "--git-dir")
-	return err == nil
-}
-
-func hasRemote(dir string) bool {
-	out, err := git(dir, "remote")
-	return err == nil && strings.TrimSpace(out) != ""
-}
-
-func hasStagedOrUntracked(dir string) bool {
-	out, _ := git(dir, "status", "--porcelain")
-	return out != ""
-}
-
 func runVaultInit(cmd *cobra.Command, args []string) error {
 	dir, err := vaultDir()
 	if err != nil {
 		return err
 	}
 
-	if isGitRepo(dir) {
-		fmt.Printf("vault already initialized at %s\n", dir)
-	} else {
-		if err := gitQuiet(dir, "init"); err != nil {
-			return fmt.Errorf("git init: %w", err)
-		}
+	v, created, err := data.Init(dir)
+	if err != nil {
+		return err
+	}
+	if created {
 		fmt.Printf("initialized vault at %s\n", dir)
+	} else {
+		fmt.Printf("vault already initialized at %s\n", dir)
 	}
 
+	enableSigningIfAvailable(v)
+
 	// Create .gitignore if missing
 	gitignorePath := dir + "/.gitignore"
 	if _, err := os.Stat(gitignorePath); os.IsNotExist(err) {
 		os.WriteFile(gitignorePath, []byte(".DS_Store\n*.tmp\n"), 0o644)
 	}
 
-	// Initial commit if repo is empty
-	if _, err := git(dir, "rev-parse", "HEAD"); err != nil {
-		gitQuiet(dir, "add", "-A")
-		gitQuiet(dir, "commit", "-m", "init: mind vault")
+	if _, committed, err := v.Commit("init: mind vault"); err != nil {
+		return fmt.Errorf("initial commit: %w", err)
+	} else if committed {
 		fmt.Println("created initial commit")
 	}
 
 	if flagGitHub {
-		return initGitHubRemote(dir)
+		return initGitHubRemote(v, dir)
 	}
 	if flagGitLab {
-		return initGitLabRemote(dir)
+		return initGitLabRemote(v, dir)
 	}
 
 	return nil
 }
 
-func initGitHubRemote(dir string) error {
+// initGitHubRemote and initGitLabRemote still shell out to the gh/glab CLIs
+// to create the remote repo — go-git has no equivalent, and these are
+// one-shot setup calls rather than the hot sync/push path.
+func initGitHubRemote(v *data.Vault, dir string) error {
 	if _, err := exec.LookPath("gh"); err != nil {
 		return fmt.Errorf("gh CLI not found — install it: https://cli.github.com")
 	}
 
-	if hasRemote(dir) {
+	if v.HasRemote() {
 		fmt.Println("remote already configured")
-		return pushVault(dir)
+		return v.Push(context.Background())
 	}
 
 	cmd := exec.Command("gh", "repo", "create", "mind-vault", "--private", "--source=.", "--remote=origin", "--push")
@@ -140,17 +146,16 @@ func initGitHubRemote(dir string) error {
 	return nil
 }
 
-func initGitLabRemote(dir string) error {
+func initGitLabRemote(v *data.Vault, dir string) error {
 	if _, err := exec.LookPath("glab"); err != nil {
 		return fmt.Errorf("glab CLI not found — install it: https://gitlab.com/gitlab-org/cli")
 	}
 
-	if hasRemote(dir) {
+	if v.HasRemote() {
 		fmt.Println("remote already configured")
-		return pushVault(dir)
+		return v.Push(context.Background())
 	}
 
-	// Create project
 	cmd := exec.Command("glab", "repo", "create", "mind-vault", "--private", "-y")
 	cmd.Dir = dir
 	cmd.Stdout = os.Stdout
@@ -159,17 +164,7 @@ func initGitLabRemote(dir string) error {
 		return fmt.Errorf("glab repo create: %w", err)
 	}
 
-	return pushVault(dir)
-}
-
-func pushVault(dir string) error {
-	if err := gitQuiet(dir, "push", "-u", "origin", "main"); err != nil {
-		// Try master if main doesn't exist
-		if err2 := gitQuiet(dir, "push", "-u", "origin", "master"); err2 != nil {
-			return fmt.Errorf("push failed: %w", err)
-		}
-	}
-	return nil
+	return v.Push(context.Background())
 }
 
 func runVaultSync(cmd *cobra.Command, args []string) error {
@@ -178,40 +173,66 @@ func runVaultSync(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if !isGitRepo(dir) {
+	if !data.IsVault(dir) {
 		return fmt.Errorf("vault not initialized — run: mind vault init")
 	}
 
-	// Stage and commit if there are changes
-	if hasStagedOrUntracked(dir) {
-		gitQuiet(dir, "add", "-A")
-		msg := fmt.Sprintf("sync: %s", time.Now().Format("2006-01-02 15:04"))
-		if err := gitQuiet(dir, "commit", "-m", msg); err != nil {
-			return fmt.Errorf("commit: %w", err)
-		}
+	v, err := data.OpenVault(dir)
+	if err != nil {
+		return err
+	}
+	enableSigningIfAvailable(v)
+
+	dirty, err := v.Dirty()
+	if err != nil {
+		return fmt.Errorf("checking status: %w", err)
+	}
+
+	msg := fmt.Sprintf("sync: %s", time.Now().Format("2006-01-02 15:04"))
+	if _, committed, err := v.Commit(msg); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	} else if committed {
 		fmt.Println("committed changes")
+	} else if dirty {
+		// Dirty but nothing staged (shouldn't happen, but stay honest about it).
+		fmt.Println("no changes to commit")
 	} else {
 		fmt.Println("no changes to commit")
 	}
 
-	// Pull + push if remote exists
-	if hasRemote(dir) {
-		fmt.Println("pulling...")
-		if err := gitQuiet(dir, "pull", "--rebase"); err != nil {
-			return fmt.Errorf("pull: %w", err)
-		}
-		fmt.Println("pushing...")
-		if err := gitQuiet(dir, "push"); err != nil {
-			return fmt.Errorf("push: %w", err)
-		}
-		fmt.Println("synced")
-	} else {
+	if !v.HasRemote() {
 		fmt.Println("no remote configured — run: mind vault init --github")
+		return nil
+	}
+
+	ctx := context.Background()
+
+	if flagRequireSigned {
+		if err := v.RequireSignedAhead(ctx); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("pulling...")
+	if err := v.PullWithMerge(ctx); err != nil {
+		return err
+	}
+	fmt.Println("pushing...")
+	if err := v.Push(ctx); err != nil {
+		return err
 	}
+	fmt.Println("synced")
 
 	return nil
 }
 
+// enableSigningIfAvailable loads the user's signing key if one is
+// configured. Signing is opt-in — a missing key is not an error, it just
+// means commits go out unsigned.
+func enableSigningIfAvailable(v *data.Vault) {
+	_ = v.EnableSigning()
+}
+
 func runVaultStatus(cmd *cobra.Command, args []string) error {
 	dir, err := vaultDir()
 	if err != nil {
@@ -220,24 +241,38 @@ func runVaultStatus(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("vault: %s\n", dir)
 
-	if !isGitRepo(dir) {
+	if !data.IsVault(dir) {
 		fmt.Println("status: not initialized")
 		return nil
 	}
 
+	v, err := data.OpenVault(dir)
+	if err != nil {
+		return err
+	}
+	enableSigningIfAvailable(v)
+
 	// Show remote
-	if remote, err := git(dir, "remote", "get-url", "origin"); err == nil {
+	if remote, err := v.RemoteURL(); err == nil {
 		fmt.Printf("remote: %s\n", remote)
 	} else {
 		fmt.Println("remote: none")
 	}
 
 	// Show status
-	out, _ := git(dir, "status", "--short")
-	if out == "" {
+	status, err := v.Status()
+	if err != nil {
+		return fmt.Errorf("status: %w", err)
+	}
+	if status.IsClean() {
 		fmt.Println("status: clean")
 	} else {
-		fmt.Printf("status:\n%s\n", out)
+		fmt.Printf("status:\n%s\n", status.String())
+	}
+
+	if trusts, err := v.VerifyLog(); err == nil && len(trusts) > 0 {
+		latest := trusts[0]
+		fmt.Printf("signature: %s %s (%s)\n", latest.Symbol, latest.Detail, latest.Hash[:10])
 	}
 
 	// File count
@@ -252,3 +287,104 @@ func runVaultStatus(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runVaultPull(cmd *cobra.Command, args []string) error {
+	dir, err := vaultDir()
+	if err != nil {
+		return err
+	}
+
+	if !data.IsVault(dir) {
+		return fmt.Errorf("vault not initialized — run: mind vault init")
+	}
+
+	sources, err := data.LoadSources(dir)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		fmt.Println("no sources configured — add entries to ~/.mind/sources.json")
+		return nil
+	}
+
+	v, err := data.OpenVault(dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("pulling %d source(s)...\n", len(sources))
+	if err := v.PullSources(context.Background(), sources); err != nil {
+		return err
+	}
+	fmt.Println("pull complete")
+
+	return nil
+}
+
+func runVaultVerify(cmd *cobra.Command, args []string) error {
+	dir, err := vaultDir()
+	if err != nil {
+		return err
+	}
+
+	if !data.IsVault(dir) {
+		return fmt.Errorf("vault not initialized — run: mind vault init")
+	}
+
+	v, err := data.OpenVault(dir)
+	if err != nil {
+		return err
+	}
+	if err := v.EnableSigning(); err != nil {
+		fmt.Printf("warning: %v — signed commits will be reported as unknown key\n", err)
+	}
+
+	trusts, err := v.VerifyLog()
+	if err != nil {
+		return err
+	}
+
+	for _, t := range trusts {
+		fmt.Printf("%s %s %s — %s\n", t.Hash[:10], t.Symbol, t.Detail, t.Message)
+	}
+
+	return nil
+}
+
+// runVaultMerge implements `mind vault merge <base> <ours> <theirs> <out>`,
+// the signature git invokes a merge driver with (%O %A %B %A).
+func runVaultMerge(cmd *cobra.Command, args []string) error {
+	basePath, oursPath, theirsPath, outPath := args[0], args[1], args[2], args[3]
+
+	base, err := loadMergeSide(basePath)
+	if err != nil {
+		return err
+	}
+	ours, err := data.LoadProject(oursPath)
+	if err != nil {
+		return err
+	}
+	theirs, err := data.LoadProject(theirsPath)
+	if err != nil {
+		return err
+	}
+
+	merged := data.MergeProjects(base, ours, theirs)
+
+	body, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outPath, body, 0o644)
+}
+
+// loadMergeSide loads the base side of a merge, tolerating an empty or
+// missing file — git passes an empty base when the file was added
+// independently on both sides.
+func loadMergeSide(path string) (*data.Project, error) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() == 0 {
+		return nil, nil
+	}
+	return data.LoadProject(path)
+}
@@ -0,0 +1,170 @@
+package data
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// indexEntry is a cached project summary plus enough file metadata to tell
+// whether the project file changed since it was last parsed.
+type indexEntry struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	NodeCount int       `json:"node_count"`
+	UpdatedAt time.Time `json:"updated_at"`
+	SHA1      string    `json:"sha1_of_file"`
+	ModTime   time.Time `json:"mod_time"`
+	Size      int64     `json:"size"`
+}
+
+// Index is a persistent cache of project summaries backed by
+// ~/.mind/index.json, keyed by file path relative to the vault root. It lets LoadProjectSummaries scale
+// with the number of changed files rather than the total number of projects
+// in the vault.
+type Index struct {
+	dir     string
+	path    string
+	entries map[string]indexEntry
+}
+
+// OpenIndex loads the index for dataDir, or starts an empty one if
+// index.json doesn't exist yet.
+func OpenIndex(dataDir string) (*Index, error) {
+	idx := &Index{
+		dir:     dataDir,
+		path:    filepath.Join(dataDir, "index.json"),
+		entries: make(map[string]indexEntry),
+	}
+
+	body, err := os.ReadFile(idx.path)
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading index: %w", err)
+	}
+
+	if err := json.Unmarshal(body, &idx.entries); err != nil {
+		return nil, fmt.Errorf("parsing index: %w", err)
+	}
+	return idx, nil
+}
+
+// Summaries returns a ProjectSummary for every *.json project file in the
+// vault — including ones nested under imported/<source-id>/ by `mind vault
+// pull` — re-parsing only files whose size or mtime differ from what's
+// cached. Cache entries are keyed by path relative to the vault root so
+// that imported files sharing a basename with another source don't
+// collide.
+func (idx *Index) Summaries() ([]ProjectSummary, error) {
+	seen := make(map[string]bool)
+	var summaries []ProjectSummary
+	dirty := false
+
+	err := filepath.WalkDir(idx.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if filepath.Ext(path) != ".json" || strings.HasSuffix(path, ".tmp") || vaultMetaFiles[filepath.Base(path)] {
+			return nil
+		}
+		rel, err := filepath.Rel(idx.dir, path)
+		if err != nil {
+			return nil
+		}
+		seen[rel] = true
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		entry, ok := idx.entries[rel]
+		if !ok || entry.Size != info.Size() || !entry.ModTime.Equal(info.ModTime()) {
+			entry, err = idx.reindex(path, info)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: skipping %s: %v\n", path, err)
+				return nil
+			}
+			idx.entries[rel] = entry
+			dirty = true
+		}
+
+		summaries = append(summaries, ProjectSummary{ID: entry.ID, Name: entry.Name})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for rel := range idx.entries {
+		if !seen[rel] {
+			delete(idx.entries, rel)
+			dirty = true
+		}
+	}
+
+	if dirty {
+		if err := idx.save(); err != nil {
+			return nil, err
+		}
+	}
+
+	return summaries, nil
+}
+
+// Invalidate drops the cached entry for <id>.json at the vault root, forcing
+// that file to be re-parsed on the next call to Summaries, e.g. after
+// SaveProject writes it. Entries for files under imported/ are not touched.
+func (idx *Index) Invalidate(id string) {
+	delete(idx.entries, id+".json")
+}
+
+// Rebuild discards the entire cache and re-parses every project file.
+func (idx *Index) Rebuild() error {
+	idx.entries = make(map[string]indexEntry)
+	_, err := idx.Summaries()
+	return err
+}
+
+func (idx *Index) reindex(path string, info os.FileInfo) (indexEntry, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return indexEntry{}, err
+	}
+
+	var p Project
+	if err := json.Unmarshal(body, &p); err != nil {
+		return indexEntry{}, err
+	}
+
+	sum := sha1.Sum(body)
+	return indexEntry{
+		ID:        p.ID,
+		Name:      p.Name,
+		NodeCount: len(p.Nodes),
+		UpdatedAt: time.Now(),
+		SHA1:      fmt.Sprintf("%x", sum),
+		ModTime:   info.ModTime(),
+		Size:      info.Size(),
+	}, nil
+}
+
+func (idx *Index) save() error {
+	body, err := json.MarshalIndent(idx.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := idx.path + ".tmp"
+	if err := os.WriteFile(tmpPath, body, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, idx.path)
+}
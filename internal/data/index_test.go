@@ -0,0 +1,60 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSummariesSkipsVaultMetaFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	write(t, filepath.Join(dir, "p1.json"), `{"id":"p1","name":"Project One","nodes":[]}`)
+	write(t, filepath.Join(dir, "index.json"), `{}`)
+	write(t, filepath.Join(dir, "sources.json"), `[]`)
+
+	idx, err := OpenIndex(dir)
+	if err != nil {
+		t.Fatalf("OpenIndex: %v", err)
+	}
+
+	summaries, err := idx.Summaries()
+	if err != nil {
+		t.Fatalf("Summaries: %v", err)
+	}
+
+	if len(summaries) != 1 || summaries[0].ID != "p1" {
+		t.Errorf("expected only p1 in summaries, got: %+v", summaries)
+	}
+}
+
+func TestSummariesReindexesOnChange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "p1.json")
+	write(t, path, `{"id":"p1","name":"Original","nodes":[]}`)
+
+	idx, err := OpenIndex(dir)
+	if err != nil {
+		t.Fatalf("OpenIndex: %v", err)
+	}
+	if _, err := idx.Summaries(); err != nil {
+		t.Fatalf("Summaries: %v", err)
+	}
+
+	write(t, path, `{"id":"p1","name":"Renamed","nodes":[]}`)
+
+	summaries, err := idx.Summaries()
+	if err != nil {
+		t.Fatalf("Summaries: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].Name != "Renamed" {
+		t.Errorf("expected reindexed summary to reflect the rename, got: %+v", summaries)
+	}
+}
+
+func write(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
@@ -3,17 +3,20 @@ package data
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type NodeData struct {
-	Label       string `json:"label"`
-	Type        string `json:"type"`
-	Description string `json:"description,omitempty"`
-	Assignee    string `json:"assignee,omitempty"`
-	Status      string `json:"status,omitempty"`
+	Label       string    `json:"label"`
+	Type        string    `json:"type"`
+	Description string    `json:"description,omitempty"`
+	Assignee    string    `json:"assignee,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
 }
 
 type Node struct {
@@ -45,6 +48,15 @@ type ProjectSummary struct {
 	Name string `json:"name"`
 }
 
+// vaultMetaFiles are reserved *.json filenames in the vault directory that
+// hold mind's own bookkeeping (the project index, configured pull sources)
+// rather than project data. Anything that globs *.json over the vault must
+// skip these.
+var vaultMetaFiles = map[string]bool{
+	"index.json":   true,
+	"sources.json": true,
+}
+
 // LoadProject reads and parses a single JSON project file.
 func LoadProject(path string) (*Project, error) {
 	data, err := os.ReadFile(path)
@@ -60,7 +72,8 @@ func LoadProject(path string) (*Project, error) {
 	return &p, nil
 }
 
-// SaveProject saves a project to a JSON file using an atomic write.
+// SaveProject saves a project to a JSON file using an atomic write, then
+// refreshes its row in the project index.
 func SaveProject(dataDir string, p *Project) error {
 	body, err := json.MarshalIndent(p, "", "  ")
 	if err != nil {
@@ -74,53 +87,56 @@ func SaveProject(dataDir string, p *Project) error {
 		return err
 	}
 
-	return os.Rename(tmpPath, filePath)
-}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return err
+	}
 
-// LoadAllProjects loads all JSON files from the given directory.
-func LoadAllProjects(dataDir string) ([]*Project, error) {
-	matches, err := filepath.Glob(filepath.Join(dataDir, "*.json"))
+	idx, err := OpenIndex(dataDir)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	idx.Invalidate(p.ID)
+	_, err = idx.Summaries()
+	return err
+}
 
+// LoadAllProjects loads every JSON project file under dataDir, including
+// ones nested under imported/<source-id>/ by `mind vault pull` — a plain
+// *.json glob over dataDir's root would never see those.
+func LoadAllProjects(dataDir string) ([]*Project, error) {
 	var projects []*Project
-	for _, path := range matches {
-		if strings.HasSuffix(path, ".tmp") {
-			continue
+	err := filepath.WalkDir(dataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
 		}
+		if filepath.Ext(path) != ".json" || strings.HasSuffix(path, ".tmp") || vaultMetaFiles[filepath.Base(path)] {
+			return nil
+		}
+
 		p, err := LoadProject(path)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "warning: skipping %s: %v\n", path, err)
-			continue
+			return nil
 		}
 		projects = append(projects, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return projects, nil
 }
 
-// LoadProjectSummaries returns a list of project metadata without loading full node data.
+// LoadProjectSummaries returns a list of project metadata without loading
+// full node data. It's backed by the index in ~/.mind/index.json, so
+// unchanged project files aren't re-parsed on every call.
 func LoadProjectSummaries(dataDir string) ([]ProjectSummary, error) {
-	matches, err := filepath.Glob(filepath.Join(dataDir, "*.json"))
+	idx, err := OpenIndex(dataDir)
 	if err != nil {
 		return nil, err
 	}
-
-	var summaries []ProjectSummary
-	for _, path := range matches {
-		if strings.HasSuffix(path, ".tmp") {
-			continue
-		}
-		// Still need to parse enough to get the name and id, or we could infer id from filename.
-		// For now, let's just parse the full thing since Go is fast, but we return a smaller slice.
-		p, err := LoadProject(path)
-		if err != nil {
-			continue
-		}
-		summaries = append(summaries, ProjectSummary{ID: p.ID, Name: p.Name})
-	}
-	return summaries, nil
+	return idx.Summaries()
 }
 
 // VaultDir returns the path to ~/.mind/, creating it if needed.
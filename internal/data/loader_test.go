@@ -0,0 +1,35 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestImportedProjectRoundTrips exercises the layout `mind vault pull`
+// produces — a project materialized under imported/<source-id>/ rather
+// than at the vault root — and confirms both loaders actually see it.
+func TestImportedProjectRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	importDir := filepath.Join(dir, "imported", "s1")
+	if err := os.MkdirAll(importDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	write(t, filepath.Join(importDir, "proj1.json"), `{"id":"proj1","name":"Imported Project","nodes":[]}`)
+
+	projects, err := LoadAllProjects(dir)
+	if err != nil {
+		t.Fatalf("LoadAllProjects: %v", err)
+	}
+	if len(projects) != 1 || projects[0].ID != "proj1" {
+		t.Fatalf("expected LoadAllProjects to find the imported project, got: %+v", projects)
+	}
+
+	summaries, err := LoadProjectSummaries(dir)
+	if err != nil {
+		t.Fatalf("LoadProjectSummaries: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].ID != "proj1" {
+		t.Fatalf("expected LoadProjectSummaries to find the imported project, got: %+v", summaries)
+	}
+}
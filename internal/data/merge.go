@@ -0,0 +1,191 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MergeProjects performs a three-way, node-by-node and edge-by-edge merge
+// of a project edited concurrently on two machines. Nodes are unioned by
+// ID; when both sides touch the same node, the one with the later
+// NodeData.UpdatedAt wins, and a deletion on one side is honored unless the
+// other side also changed that node since base. If both sides touched a
+// node at the same timestamp and disagree, a conflict marker node is added
+// rather than silently picking one. base may be nil if the project didn't
+// exist there (both sides created it independently).
+func MergeProjects(base, ours, theirs *Project) *Project {
+	merged := &Project{
+		ID:   pickNonEmpty(ours.ID, theirs.ID),
+		Name: pickNonEmpty(ours.Name, theirs.Name),
+	}
+
+	var baseNodes []Node
+	if base != nil {
+		baseNodes = base.Nodes
+	}
+	var baseEdges []Edge
+	if base != nil {
+		baseEdges = base.Edges
+	}
+	merged.Nodes = mergeNodes(baseNodes, ours.Nodes, theirs.Nodes)
+	merged.Edges = mergeEdges(baseEdges, ours.Edges, theirs.Edges)
+
+	return merged
+}
+
+func pickNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
+
+func indexNodes(nodes []Node) map[string]Node {
+	m := make(map[string]Node, len(nodes))
+	for _, n := range nodes {
+		m[n.ID] = n
+	}
+	return m
+}
+
+func indexEdges(edges []Edge) map[string]Edge {
+	m := make(map[string]Edge, len(edges))
+	for _, e := range edges {
+		m[e.ID] = e
+	}
+	return m
+}
+
+// mergeNodes unions nodes present in ours and/or theirs by ID. A node
+// missing from one side is treated as a deletion and dropped unless the
+// present side changed it since base, in which case the edit wins over the
+// deletion.
+func mergeNodes(base, ours, theirs []Node) []Node {
+	baseByID := indexNodes(base)
+	oursByID := indexNodes(ours)
+	theirsByID := indexNodes(theirs)
+
+	seen := make(map[string]bool, len(ours)+len(theirs))
+	var order []string
+	for _, n := range ours {
+		if !seen[n.ID] {
+			seen[n.ID] = true
+			order = append(order, n.ID)
+		}
+	}
+	for _, n := range theirs {
+		if !seen[n.ID] {
+			seen[n.ID] = true
+			order = append(order, n.ID)
+		}
+	}
+
+	var merged []Node
+	for _, id := range order {
+		o, hasOurs := oursByID[id]
+		t, hasTheirs := theirsByID[id]
+
+		switch {
+		case hasOurs && hasTheirs:
+			if b, ok := baseByID[id]; ok && reflect.DeepEqual(b.Data, o.Data) {
+				merged = append(merged, t) // only theirs changed it since base
+				continue
+			}
+			if b, ok := baseByID[id]; ok && reflect.DeepEqual(b.Data, t.Data) {
+				merged = append(merged, o) // only ours changed it since base
+				continue
+			}
+			winner, conflict := resolveNode(o, t)
+			merged = append(merged, winner)
+			if conflict != nil {
+				merged = append(merged, *conflict)
+			}
+		case hasOurs && !hasTheirs:
+			if b, ok := baseByID[id]; ok && reflect.DeepEqual(b.Data, o.Data) {
+				continue // deleted by theirs, unchanged by ours since base
+			}
+			merged = append(merged, o)
+		case hasTheirs && !hasOurs:
+			if b, ok := baseByID[id]; ok && reflect.DeepEqual(b.Data, t.Data) {
+				continue // deleted by ours, unchanged by theirs since base
+			}
+			merged = append(merged, t)
+		}
+	}
+
+	return merged
+}
+
+// resolveNode picks the node with the later UpdatedAt. On a tie with
+// differing data, it keeps ours as canonical and returns a conflict marker
+// node describing both sides.
+func resolveNode(ours, theirs Node) (winner Node, conflict *Node) {
+	switch {
+	case reflect.DeepEqual(ours.Data, theirs.Data):
+		return ours, nil
+	case ours.Data.UpdatedAt.After(theirs.Data.UpdatedAt):
+		return ours, nil
+	case theirs.Data.UpdatedAt.After(ours.Data.UpdatedAt):
+		return theirs, nil
+	default:
+		return ours, &Node{
+			ID:   ours.ID + "-conflict",
+			Type: "conflict",
+			Data: NodeData{
+				Label:       fmt.Sprintf("conflict on %q", ours.ID),
+				Description: fmt.Sprintf("ours: %q theirs: %q", ours.Data.Label, theirs.Data.Label),
+				Status:      "blocked",
+			},
+		}
+	}
+}
+
+// mergeEdges unions edges present in ours and/or theirs by ID, using the
+// same base-consulting deletion rule as mergeNodes: an edge missing from one
+// side is dropped unless the present side changed it since base, in which
+// case the edit wins over the deletion. On a same-ID conflict (both sides
+// still have the edge but disagree on source/target/type), ours wins —
+// edges carry no UpdatedAt to break ties on the way resolveNode does.
+func mergeEdges(base, ours, theirs []Edge) []Edge {
+	baseByID := indexEdges(base)
+	oursByID := indexEdges(ours)
+	theirsByID := indexEdges(theirs)
+
+	seen := make(map[string]bool, len(ours)+len(theirs))
+	var order []string
+	for _, e := range ours {
+		if !seen[e.ID] {
+			seen[e.ID] = true
+			order = append(order, e.ID)
+		}
+	}
+	for _, e := range theirs {
+		if !seen[e.ID] {
+			seen[e.ID] = true
+			order = append(order, e.ID)
+		}
+	}
+
+	var merged []Edge
+	for _, id := range order {
+		o, hasOurs := oursByID[id]
+		t, hasTheirs := theirsByID[id]
+
+		switch {
+		case hasOurs && hasTheirs:
+			merged = append(merged, o) // ours wins a same-ID conflict
+		case hasOurs && !hasTheirs:
+			if b, ok := baseByID[id]; ok && reflect.DeepEqual(b, o) {
+				continue // deleted by theirs, unchanged by ours since base
+			}
+			merged = append(merged, o)
+		case hasTheirs && !hasOurs:
+			if b, ok := baseByID[id]; ok && reflect.DeepEqual(b, t) {
+				continue // deleted by ours, unchanged by theirs since base
+			}
+			merged = append(merged, t)
+		}
+	}
+
+	return merged
+}
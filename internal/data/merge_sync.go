@@ -0,0 +1,223 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// PullWithMerge pulls like Pull, but if the branches have diverged it falls
+// back to a semantic three-way merge of every *.json project file instead
+// of failing with `<<<<<<<` conflict markers.
+func (v *Vault) PullWithMerge(ctx context.Context) error {
+	if err := v.Pull(ctx); err == nil || !isNonFastForward(err) {
+		return err
+	}
+
+	head, err := v.repo.Head()
+	if err != nil {
+		return err
+	}
+	ours, err := v.repo.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+
+	remote, err := v.repo.Remote("origin")
+	if err != nil {
+		return err
+	}
+	auth, err := v.auth()
+	if err != nil {
+		return err
+	}
+	if err := remote.FetchContext(ctx, &git.FetchOptions{Auth: auth}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("fetch: %w", err)
+	}
+
+	remoteRef, err := v.repo.Reference(plumbing.NewRemoteReferenceName("origin", head.Name().Short()), true)
+	if err != nil {
+		return fmt.Errorf("resolving remote branch: %w", err)
+	}
+	theirs, err := v.repo.CommitObject(remoteRef.Hash())
+	if err != nil {
+		return err
+	}
+
+	bases, err := ours.MergeBase(theirs)
+	if err != nil || len(bases) == 0 {
+		return fmt.Errorf("finding merge base: %w", err)
+	}
+
+	if err := v.mergeProjectFiles(bases[0], ours, theirs); err != nil {
+		return err
+	}
+
+	wt, err := v.repo.Worktree()
+	if err != nil {
+		return err
+	}
+	if _, err := wt.Add("."); err != nil {
+		return err
+	}
+
+	opts := &git.CommitOptions{
+		All:     true,
+		Parents: []plumbing.Hash{head.Hash(), remoteRef.Hash()},
+		Author: &object.Signature{
+			Name:  "mind",
+			Email: "mind@localhost",
+			When:  time.Now(),
+		},
+	}
+	if v.signKey != nil {
+		opts.SignKey = v.signKey.entity
+	}
+	_, err = wt.Commit("merge: reconcile diverged vault", opts)
+	return err
+}
+
+// mergeProjectFiles reconciles every project file across base/ours/theirs,
+// enumerating the union of filenames found in the ours and theirs trees
+// (base is consulted per name to tell a deletion from an addition) — not
+// just what's already present in the local worktree — so a project that was
+// only ever created upstream and never pulled locally still gets
+// materialized instead of silently dropped from the merge.
+func (v *Vault) mergeProjectFiles(base, ours, theirs *object.Commit) error {
+	baseNames, err := projectFileNames(base)
+	if err != nil {
+		return err
+	}
+	oursNames, err := projectFileNames(ours)
+	if err != nil {
+		return err
+	}
+	theirsNames, err := projectFileNames(theirs)
+	if err != nil {
+		return err
+	}
+
+	all := make(map[string]bool, len(oursNames)+len(theirsNames))
+	for name := range oursNames {
+		all[name] = true
+	}
+	for name := range theirsNames {
+		all[name] = true
+	}
+
+	for name := range all {
+		path := filepath.Join(v.dir, name)
+
+		switch {
+		case oursNames[name] && theirsNames[name]:
+			oursP, err := projectAtCommit(ours, name)
+			if err != nil {
+				return fmt.Errorf("reading %s from local history: %w", name, err)
+			}
+			theirsP, err := projectAtCommit(theirs, name)
+			if err != nil {
+				return fmt.Errorf("reading %s from remote history: %w", name, err)
+			}
+
+			var baseP *Project
+			if baseNames[name] {
+				baseP, err = projectAtCommit(base, name)
+				if err != nil {
+					return fmt.Errorf("reading %s from merge base: %w", name, err)
+				}
+			}
+
+			if err := writeMergedProject(path, MergeProjects(baseP, oursP, theirsP)); err != nil {
+				return err
+			}
+
+		case theirsNames[name] && !oursNames[name]:
+			if baseNames[name] {
+				continue // deleted locally since base — honor the deletion
+			}
+			theirsP, err := projectAtCommit(theirs, name)
+			if err != nil {
+				return fmt.Errorf("reading %s from remote history: %w", name, err)
+			}
+			if err := writeMergedProject(path, theirsP); err != nil {
+				return err
+			}
+
+		case oursNames[name] && !theirsNames[name]:
+			if baseNames[name] {
+				// deleted remotely since base — honor the deletion locally too
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+			// else: created locally since base and the remote never had it — keep it as-is
+		}
+	}
+
+	return nil
+}
+
+// projectFileNames recursively lists every *.json project filename tracked
+// at commit c — including ones nested under imported/<source-id>/ — minus
+// the vault's own bookkeeping files. tree.Entries only covers the tree's
+// immediate children, which used to make nested imports invisible to the
+// merge; tree.Files() walks the whole tree.
+func projectFileNames(c *object.Commit) (map[string]bool, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if filepath.Ext(f.Name) == ".json" && !vaultMetaFiles[filepath.Base(f.Name)] {
+			names[f.Name] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+func projectAtCommit(c *object.Commit, name string) (*Project, error) {
+	f, err := c.File(name)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := f.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	var p Project
+	if err := json.Unmarshal([]byte(contents), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func writeMergedProject(path string, p *Project) error {
+	body, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, body, 0o644)
+}
+
+func isNonFastForward(err error) bool {
+	return errors.Is(err, git.ErrNonFastForwardUpdate) || strings.Contains(err.Error(), "non-fast-forward")
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func headHash(t *testing.T, repo *git.Repository) plumbing.Hash {
+	t.Helper()
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head: %v", err)
+	}
+	return head.Hash()
+}
+
+func commitAll(t *testing.T, wt *git.Worktree, msg string) {
+	t.Helper()
+	if _, err := wt.Add("."); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	_, err := wt.Commit(msg, &git.CommitOptions{
+		All:    true,
+		Author: &object.Signature{Name: "mind", Email: "mind@localhost", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+}
+
+// TestMergeProjectFilesKeepsFilesUniqueToOneSide reproduces a diverged sync
+// where one machine edits an existing project while another — independently
+// — adds a project nested under imported/<source-id>/ that the first
+// machine has never pulled. The merge must not silently drop it.
+func TestMergeProjectFilesKeepsFilesUniqueToOneSide(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree: %v", err)
+	}
+
+	write(t, filepath.Join(dir, "root.json"), `{"id":"root","name":"Root","nodes":[]}`)
+	commitAll(t, wt, "base")
+	baseHash := headHash(t, repo)
+
+	write(t, filepath.Join(dir, "root.json"), `{"id":"root","name":"Root Edited","nodes":[]}`)
+	commitAll(t, wt, "ours edits root")
+	oursHash := headHash(t, repo)
+
+	if err := wt.Checkout(&git.CheckoutOptions{Hash: baseHash, Force: true}); err != nil {
+		t.Fatalf("Checkout base: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "imported", "s1"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	write(t, filepath.Join(dir, "imported", "s1", "nested.json"), `{"id":"nested","name":"Nested","nodes":[]}`)
+	commitAll(t, wt, "theirs adds an imported project")
+	theirsHash := headHash(t, repo)
+
+	base, err := repo.CommitObject(baseHash)
+	if err != nil {
+		t.Fatalf("CommitObject(base): %v", err)
+	}
+	ours, err := repo.CommitObject(oursHash)
+	if err != nil {
+		t.Fatalf("CommitObject(ours): %v", err)
+	}
+	theirs, err := repo.CommitObject(theirsHash)
+	if err != nil {
+		t.Fatalf("CommitObject(theirs): %v", err)
+	}
+
+	v := &Vault{dir: dir, repo: repo}
+	if err := v.mergeProjectFiles(base, ours, theirs); err != nil {
+		t.Fatalf("mergeProjectFiles: %v", err)
+	}
+
+	nestedPath := filepath.Join(dir, "imported", "s1", "nested.json")
+	body, err := os.ReadFile(nestedPath)
+	if err != nil {
+		t.Fatalf("expected the project only present in theirs to be materialized, got: %v", err)
+	}
+	var p Project
+	if err := json.Unmarshal(body, &p); err != nil {
+		t.Fatalf("unmarshaling merged nested project: %v", err)
+	}
+	if p.ID != "nested" {
+		t.Errorf("expected nested project id %q, got %q", "nested", p.ID)
+	}
+
+	rootPath := filepath.Join(dir, "root.json")
+	rootBody, err := os.ReadFile(rootPath)
+	if err != nil {
+		t.Fatalf("reading merged root.json: %v", err)
+	}
+	var root Project
+	if err := json.Unmarshal(rootBody, &root); err != nil {
+		t.Fatalf("unmarshaling merged root project: %v", err)
+	}
+	if root.Name != "Root Edited" {
+		t.Errorf("expected ours' edit to survive the merge, got name %q", root.Name)
+	}
+}
@@ -0,0 +1,98 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeProjectsNodeOnlyInTheirs(t *testing.T) {
+	base := &Project{ID: "p1", Nodes: []Node{{ID: "root", Data: NodeData{Label: "Root"}}}}
+	ours := &Project{ID: "p1", Nodes: []Node{{ID: "root", Data: NodeData{Label: "Root"}}}}
+	theirs := &Project{ID: "p1", Nodes: []Node{
+		{ID: "root", Data: NodeData{Label: "Root"}},
+		{ID: "new", Data: NodeData{Label: "New Node"}},
+	}}
+
+	merged := MergeProjects(base, ours, theirs)
+
+	if got := indexNodes(merged.Nodes); got["new"].ID != "new" {
+		t.Errorf("expected merged project to contain node added only by theirs, got nodes: %+v", merged.Nodes)
+	}
+}
+
+func TestMergeProjectsDeletionHonoredWhenUnchanged(t *testing.T) {
+	base := &Project{ID: "p1", Nodes: []Node{
+		{ID: "root", Data: NodeData{Label: "Root"}},
+		{ID: "gone", Data: NodeData{Label: "Gone"}},
+	}}
+	ours := &Project{ID: "p1", Nodes: []Node{{ID: "root", Data: NodeData{Label: "Root"}}}}
+	theirs := &Project{ID: "p1", Nodes: []Node{
+		{ID: "root", Data: NodeData{Label: "Root"}},
+		{ID: "gone", Data: NodeData{Label: "Gone"}},
+	}}
+
+	merged := MergeProjects(base, ours, theirs)
+
+	if _, ok := indexNodes(merged.Nodes)["gone"]; ok {
+		t.Errorf("expected node deleted by ours and unchanged by theirs to stay deleted, got nodes: %+v", merged.Nodes)
+	}
+}
+
+func TestMergeProjectsEditBeatsUnchangedDeletion(t *testing.T) {
+	base := &Project{ID: "p1", Nodes: []Node{{ID: "n1", Data: NodeData{Label: "Old"}}}}
+	ours := &Project{ID: "p1", Nodes: []Node{}}
+	theirs := &Project{ID: "p1", Nodes: []Node{
+		{ID: "n1", Data: NodeData{Label: "Updated", UpdatedAt: time.Now()}},
+	}}
+
+	merged := MergeProjects(base, ours, theirs)
+
+	n, ok := indexNodes(merged.Nodes)["n1"]
+	if !ok {
+		t.Fatalf("expected the edit on theirs to win over the unchanged deletion from ours, got nodes: %+v", merged.Nodes)
+	}
+	if n.Data.Label != "Updated" {
+		t.Errorf("expected surviving node to carry theirs' edit, got label %q", n.Data.Label)
+	}
+}
+
+func TestMergeProjectsConflictOnSimultaneousEdit(t *testing.T) {
+	base := &Project{ID: "p1", Nodes: []Node{{ID: "n1", Data: NodeData{Label: "Old"}}}}
+	ours := &Project{ID: "p1", Nodes: []Node{{ID: "n1", Data: NodeData{Label: "Ours"}}}}
+	theirs := &Project{ID: "p1", Nodes: []Node{{ID: "n1", Data: NodeData{Label: "Theirs"}}}}
+
+	merged := MergeProjects(base, ours, theirs)
+
+	byID := indexNodes(merged.Nodes)
+	if byID["n1"].Data.Label != "Ours" {
+		t.Errorf("expected ours to win the tie on n1, got label %q", byID["n1"].Data.Label)
+	}
+	if _, ok := byID["n1-conflict"]; !ok {
+		t.Errorf("expected a conflict marker node for n1, got nodes: %+v", merged.Nodes)
+	}
+}
+
+func TestMergeProjectsEdgeDeletionHonoredWhenUnchanged(t *testing.T) {
+	e := Edge{ID: "e1", Source: "a", Target: "b", Data: EdgeData{EdgeType: "hierarchy"}}
+	base := &Project{ID: "p1", Edges: []Edge{e}}
+	ours := &Project{ID: "p1", Edges: []Edge{}}
+	theirs := &Project{ID: "p1", Edges: []Edge{e}}
+
+	merged := MergeProjects(base, ours, theirs)
+
+	if len(merged.Edges) != 0 {
+		t.Errorf("expected edge deleted by ours and unchanged by theirs to stay deleted, got edges: %+v", merged.Edges)
+	}
+}
+
+func TestMergeProjectsEdgeEditBeatsUnchangedDeletion(t *testing.T) {
+	base := &Project{ID: "p1", Edges: []Edge{{ID: "e1", Source: "a", Target: "b", Data: EdgeData{EdgeType: "hierarchy"}}}}
+	ours := &Project{ID: "p1", Edges: []Edge{}}
+	theirs := &Project{ID: "p1", Edges: []Edge{{ID: "e1", Source: "a", Target: "c", Data: EdgeData{EdgeType: "hierarchy"}}}}
+
+	merged := MergeProjects(base, ours, theirs)
+
+	if len(merged.Edges) != 1 || merged.Edges[0].Target != "c" {
+		t.Errorf("expected theirs' edit to win over the unchanged deletion from ours, got edges: %+v", merged.Edges)
+	}
+}
@@ -0,0 +1,187 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// candidateBranches are tried in order when locating the tip of a freshly
+// fetched source, since we don't know its default branch ahead of time.
+var candidateBranches = []string{"main", "master"}
+
+// PullSources fetches every configured source into a namespaced ref
+// (refs/mind/backup/<source-id>/<branch>), materializes each source's *.json
+// project files into imported/<source-id>/ in the vault workdir, and records
+// a single "pull" commit whose parents are the previous vault HEAD plus
+// every fetched source tip — an octopus merge that keeps each source's full
+// history reachable via `git log --all` without file collisions.
+func (v *Vault) PullSources(ctx context.Context, sources []Source) error {
+	var parents []plumbing.Hash
+	if head, err := v.repo.Head(); err == nil {
+		parents = append(parents, head.Hash())
+	}
+
+	any := false
+	for _, src := range sources {
+		tip, err := v.fetchSource(ctx, src)
+		if err != nil {
+			return fmt.Errorf("fetching source %s: %w", src.ID, err)
+		}
+		if tip.IsZero() {
+			continue
+		}
+
+		if err := v.materializeSource(src, tip); err != nil {
+			return fmt.Errorf("materializing source %s: %w", src.ID, err)
+		}
+
+		parents = append(parents, tip)
+		any = true
+	}
+
+	if !any {
+		return nil
+	}
+
+	return v.commitPull(parents)
+}
+
+// fetchSource fetches src's branches into refs/mind/backup/<id>/* and
+// returns the hash of the first of candidateBranches found among the
+// fetched refs.
+func (v *Vault) fetchSource(ctx context.Context, src Source) (plumbing.Hash, error) {
+	remote := git.NewRemote(v.repo.Storer, &config.RemoteConfig{
+		Name: "backup-" + src.ID,
+		URLs: []string{src.URL},
+	})
+
+	refspec := config.RefSpec(fmt.Sprintf("+refs/heads/*:refs/mind/backup/%s/*", src.ID))
+	err := remote.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs: []config.RefSpec{refspec},
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return plumbing.ZeroHash, err
+	}
+
+	for _, branch := range candidateBranches {
+		refName := plumbing.ReferenceName(fmt.Sprintf("refs/mind/backup/%s/%s", src.ID, branch))
+		ref, err := v.repo.Reference(refName, true)
+		if err == nil {
+			return ref.Hash(), nil
+		}
+	}
+
+	return plumbing.ZeroHash, fmt.Errorf("no %v branch found after fetch", candidateBranches)
+}
+
+// materializeSource walks the tree at tip and copies every *.json file
+// (optionally scoped by src.PathFilter) into imported/<id>/ in the vault
+// workdir, preserving each file's relative directory so that files sharing
+// a basename in different subtrees don't collide, and pruning files that no
+// longer exist upstream.
+func (v *Vault) materializeSource(src Source, tip plumbing.Hash) error {
+	commit, err := v.repo.CommitObject(tip)
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	destDir := filepath.Join(v.dir, "imported", src.ID)
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if filepath.Ext(f.Name) != ".json" {
+			return nil
+		}
+		if src.PathFilter != "" && !strings.HasPrefix(f.Name, src.PathFilter) {
+			return nil
+		}
+
+		rel := filepath.Clean(f.Name)
+		if rel == ".." || strings.HasPrefix(rel, "../") || filepath.IsAbs(rel) {
+			return nil // defend against a malicious upstream tree escaping destDir
+		}
+
+		contents, err := f.Contents()
+		if err != nil {
+			return err
+		}
+
+		destPath := filepath.Join(destDir, rel)
+		seen[rel] = true
+		if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(destPath, []byte(contents), 0o644)
+	})
+	if err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(destDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(destDir, path)
+		if err != nil {
+			return nil
+		}
+		if !seen[rel] {
+			os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// commitPull stages the imported/ directory and records an octopus merge
+// commit across parents (vault HEAD plus every fetched source tip). If
+// nothing actually changed on disk — a repeat `pull` where every source was
+// already up to date — it's a no-op rather than an empty merge commit.
+func (v *Vault) commitPull(parents []plumbing.Hash) error {
+	wt, err := v.repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	if _, err := wt.Add("."); err != nil {
+		return fmt.Errorf("staging imports: %w", err)
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return err
+	}
+	if status.IsClean() {
+		return nil
+	}
+
+	_, err = wt.Commit("pull: aggregate sources", &git.CommitOptions{
+		All:     true,
+		Parents: parents,
+		Author: &object.Signature{
+			Name:  "mind",
+			Email: "mind@localhost",
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("committing pull: %w", err)
+	}
+	return nil
+}
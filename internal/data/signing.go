@@ -0,0 +1,79 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	gitconfig "github.com/go-git/go-git/v5/config"
+)
+
+// signingKey bundles a decoded PGP entity (for signing new commits) with the
+// original armored key ring text (required by go-git's CommitObject.Verify,
+// which takes the armor rather than a parsed entity).
+type signingKey struct {
+	entity  *openpgp.Entity
+	armored string
+}
+
+// signingKeyCache holds the decoded key for the process lifetime — parsing
+// an armored key ring isn't free and the key never changes mid-run.
+var signingKeyCache *signingKey
+
+// loadSigningKey loads the user's PGP signing key from
+// ~/.mind/signing-key.asc, falling back to the path named by git config
+// user.signingkey.
+func loadSigningKey(vaultDir string) (*signingKey, error) {
+	if signingKeyCache != nil {
+		return signingKeyCache, nil
+	}
+
+	body, err := os.ReadFile(filepath.Join(vaultDir, "signing-key.asc"))
+	if os.IsNotExist(err) {
+		path, cfgErr := signingKeyPathFromGitConfig()
+		if cfgErr != nil {
+			return nil, cfgErr
+		}
+		body, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key: %w", err)
+	}
+
+	entityList, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key: %w", err)
+	}
+	if len(entityList) == 0 {
+		return nil, fmt.Errorf("no keys found in signing key file")
+	}
+
+	signingKeyCache = &signingKey{entity: entityList[0], armored: string(body)}
+	return signingKeyCache, nil
+}
+
+// signingKeyPathFromGitConfig reads user.signingkey from the user's global
+// git config, which we treat as a path to an exported armored key.
+func signingKeyPathFromGitConfig() (string, error) {
+	cfg, err := gitconfig.LoadConfig(gitconfig.GlobalScope)
+	if err != nil {
+		return "", fmt.Errorf("reading global git config: %w", err)
+	}
+
+	key := cfg.Raw.Section("user").Option("signingkey")
+	if key == "" {
+		return "", fmt.Errorf("no signing key configured — add ~/.mind/signing-key.asc or set git config user.signingkey")
+	}
+	return key, nil
+}
+
+// identityName returns the first identity name on a PGP entity, for trust
+// indicator output.
+func identityName(e *openpgp.Entity) string {
+	for _, id := range e.Identities {
+		return id.Name
+	}
+	return "unknown"
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+func TestLoadSigningKeyFromVaultDir(t *testing.T) {
+	signingKeyCache = nil
+	defer func() { signingKeyCache = nil }()
+
+	entity, err := openpgp.NewEntity("Test User", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatalf("NewEntity: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatalf("armor.Encode: %v", err)
+	}
+	if err := entity.SerializePrivate(w, nil); err != nil {
+		t.Fatalf("SerializePrivate: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("closing armor writer: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "signing-key.asc"), buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	key, err := loadSigningKey(dir)
+	if err != nil {
+		t.Fatalf("loadSigningKey: %v", err)
+	}
+	if key.entity == nil {
+		t.Fatalf("expected a decoded entity")
+	}
+	if got := identityName(key.entity); got != "Test User" {
+		t.Errorf("expected identity name %q, got %q", "Test User", got)
+	}
+}
+
+func TestLoadSigningKeyMissingFile(t *testing.T) {
+	signingKeyCache = nil
+	defer func() { signingKeyCache = nil }()
+
+	dir := t.TempDir()
+	if _, err := loadSigningKey(dir); err == nil {
+		t.Errorf("expected an error when no signing key is present and git config has none either")
+	}
+}
@@ -0,0 +1,55 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Source describes an external git repository to aggregate into the vault
+// via `mind vault pull`, the way git-backup aggregates many upstream repos
+// into one.
+type Source struct {
+	ID         string `json:"id"`
+	URL        string `json:"url"`
+	PathFilter string `json:"path_filter,omitempty"`
+}
+
+func sourcesPath(vaultDir string) string {
+	return filepath.Join(vaultDir, "sources.json")
+}
+
+// LoadSources reads ~/.mind/sources.json. A missing file is not an error —
+// it simply means no sources are configured yet.
+func LoadSources(vaultDir string) ([]Source, error) {
+	body, err := os.ReadFile(sourcesPath(vaultDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sources.json: %w", err)
+	}
+
+	var sources []Source
+	if err := json.Unmarshal(body, &sources); err != nil {
+		return nil, fmt.Errorf("parsing sources.json: %w", err)
+	}
+	return sources, nil
+}
+
+// SaveSources writes the source list to ~/.mind/sources.json using an
+// atomic write, matching SaveProject's rename pattern.
+func SaveSources(vaultDir string, sources []Source) error {
+	body, err := json.MarshalIndent(sources, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := sourcesPath(vaultDir)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, body, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
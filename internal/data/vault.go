@@ -0,0 +1,382 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// Vault wraps an in-process git repository rooted at ~/.mind, so the CLI,
+// tests, and a future web UI can all drive vault operations through one
+// code path instead of shelling out to the system git binary.
+type Vault struct {
+	dir     string
+	repo    *git.Repository
+	signKey *signingKey
+}
+
+// OpenVault opens an existing vault repository at dir.
+func OpenVault(dir string) (*Vault, error) {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening vault at %s: %w", dir, err)
+	}
+	return &Vault{dir: dir, repo: repo}, nil
+}
+
+// IsVault reports whether dir is already a git repository.
+func IsVault(dir string) bool {
+	_, err := git.PlainOpen(dir)
+	return err == nil
+}
+
+// Init initializes dir as a git repository, returning the Vault. If dir is
+// already a repository, it is opened instead and initialized is false.
+func Init(dir string) (v *Vault, initialized bool, err error) {
+	if IsVault(dir) {
+		v, err = OpenVault(dir)
+		return v, false, err
+	}
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		return nil, false, fmt.Errorf("initializing vault at %s: %w", dir, err)
+	}
+	return &Vault{dir: dir, repo: repo}, true, nil
+}
+
+// EnableSigning loads the user's PGP signing key so that subsequent commits
+// are signed and Verify/VerifyLog can check signatures against it.
+func (v *Vault) EnableSigning() error {
+	key, err := loadSigningKey(v.dir)
+	if err != nil {
+		return err
+	}
+	v.signKey = key
+	return nil
+}
+
+// HasRemote reports whether the vault has an "origin" remote configured.
+func (v *Vault) HasRemote() bool {
+	_, err := v.repo.Remote("origin")
+	return err == nil
+}
+
+// RemoteURL returns the URL of the "origin" remote, if any.
+func (v *Vault) RemoteURL() (string, error) {
+	remote, err := v.repo.Remote("origin")
+	if err != nil {
+		return "", err
+	}
+	cfg := remote.Config()
+	if len(cfg.URLs) == 0 {
+		return "", errors.New("origin has no URLs")
+	}
+	return cfg.URLs[0], nil
+}
+
+// Dirty reports whether the worktree has any uncommitted changes: staged,
+// unstaged, or untracked.
+func (v *Vault) Dirty() (bool, error) {
+	wt, err := v.repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return false, err
+	}
+	return !status.IsClean(), nil
+}
+
+// Status returns the worktree status, e.g. for printing short-form output.
+func (v *Vault) Status() (git.Status, error) {
+	wt, err := v.repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	return wt.Status()
+}
+
+// Commit stages all changes and commits them if the worktree is dirty. It
+// returns the created hash and true, or an empty hash and false if there was
+// nothing to commit.
+func (v *Vault) Commit(msg string) (hash string, committed bool, err error) {
+	wt, err := v.repo.Worktree()
+	if err != nil {
+		return "", false, err
+	}
+
+	dirty, err := v.Dirty()
+	if err != nil {
+		return "", false, err
+	}
+	if !dirty {
+		return "", false, nil
+	}
+
+	if _, err := wt.Add("."); err != nil {
+		return "", false, fmt.Errorf("staging changes: %w", err)
+	}
+
+	opts := &git.CommitOptions{
+		All: true,
+		Author: &object.Signature{
+			Name:  "mind",
+			Email: "mind@localhost",
+			When:  time.Now(),
+		},
+	}
+	if v.signKey != nil {
+		opts.SignKey = v.signKey.entity
+	}
+
+	commit, err := wt.Commit(msg, opts)
+	if err != nil {
+		return "", false, fmt.Errorf("committing: %w", err)
+	}
+
+	return commit.String(), true, nil
+}
+
+// Pull fast-forwards the current branch from the "origin" remote.
+func (v *Vault) Pull(ctx context.Context) error {
+	wt, err := v.repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	auth, err := v.auth()
+	if err != nil {
+		return err
+	}
+
+	err = wt.PullContext(ctx, &git.PullOptions{
+		RemoteName: "origin",
+		Auth:       auth,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("pull: %w", err)
+	}
+	return nil
+}
+
+// Push pushes the current branch to the "origin" remote.
+func (v *Vault) Push(ctx context.Context) error {
+	auth, err := v.auth()
+	if err != nil {
+		return err
+	}
+
+	err = v.repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: "origin",
+		Auth:       auth,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("push: %w", err)
+	}
+	return nil
+}
+
+// Sync commits any pending changes, then pulls and pushes against the
+// configured remote. It is a no-op for the network steps if no remote is
+// configured. If requireSigned is set, it refuses to fast-forward across
+// any unsigned or invalidly-signed commit on the remote.
+func (v *Vault) Sync(ctx context.Context, commitMsg string, requireSigned bool) error {
+	if _, _, err := v.Commit(commitMsg); err != nil {
+		return err
+	}
+
+	if !v.HasRemote() {
+		return nil
+	}
+
+	if requireSigned {
+		if err := v.RequireSignedAhead(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := v.PullWithMerge(ctx); err != nil {
+		return err
+	}
+	return v.Push(ctx)
+}
+
+// Log returns the commit history of the current branch, most recent first.
+func (v *Vault) Log() ([]*object.Commit, error) {
+	head, err := v.repo.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := v.repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	})
+	return commits, err
+}
+
+// CommitTrust is the signature state of a single commit, for status/verify
+// output.
+type CommitTrust struct {
+	Hash    string
+	Message string
+	Symbol  string // "✓", "✗", or "?"
+	Detail  string
+}
+
+// VerifyLog walks the commit log and checks each commit's signature against
+// the loaded signing key. Call EnableSigning first; without a loaded key
+// every signed commit is reported as "? unknown key" since there's nothing
+// to verify against. Unsigned commits are reported as "? unsigned" either
+// way.
+func (v *Vault) VerifyLog() ([]CommitTrust, error) {
+	commits, err := v.Log()
+	if err != nil {
+		return nil, err
+	}
+
+	trusts := make([]CommitTrust, 0, len(commits))
+	for _, c := range commits {
+		trusts = append(trusts, v.verifyCommit(c))
+	}
+	return trusts, nil
+}
+
+func (v *Vault) verifyCommit(c *object.Commit) CommitTrust {
+	t := CommitTrust{Hash: c.Hash.String(), Message: strings.SplitN(c.Message, "\n", 2)[0]}
+
+	switch {
+	case c.PGPSignature == "":
+		t.Symbol, t.Detail = "?", "unsigned"
+	case v.signKey == nil:
+		t.Symbol, t.Detail = "?", "unknown key"
+	default:
+		entity, err := c.Verify(v.signKey.armored)
+		if err != nil {
+			t.Symbol, t.Detail = "✗", "bad signature"
+		} else {
+			t.Symbol, t.Detail = "✓", fmt.Sprintf("signed by %s", identityName(entity))
+		}
+	}
+	return t
+}
+
+// RequireSignedAhead fetches the current branch from "origin" without
+// merging and verifies every commit between the local HEAD and the remote
+// tip, returning an error naming the first unsigned or invalidly-signed
+// commit found. Used to refuse `vault sync --require-signed` before
+// fast-forwarding across it.
+func (v *Vault) RequireSignedAhead(ctx context.Context) error {
+	if v.signKey == nil {
+		return errors.New("no signing key loaded — cannot verify remote commits")
+	}
+
+	remote, err := v.repo.Remote("origin")
+	if err != nil {
+		return err
+	}
+	auth, err := v.auth()
+	if err != nil {
+		return err
+	}
+	if err := remote.FetchContext(ctx, &git.FetchOptions{Auth: auth}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("fetch: %w", err)
+	}
+
+	head, err := v.repo.Head()
+	if err != nil {
+		return err
+	}
+	remoteRef, err := v.repo.Reference(plumbing.NewRemoteReferenceName("origin", head.Name().Short()), true)
+	if err != nil {
+		return fmt.Errorf("resolving remote branch: %w", err)
+	}
+
+	headCommit, err := v.repo.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+	remoteCommit, err := v.repo.CommitObject(remoteRef.Hash())
+	if err != nil {
+		return err
+	}
+	bases, err := headCommit.MergeBase(remoteCommit)
+	if err != nil {
+		return fmt.Errorf("finding merge base: %w", err)
+	}
+	stopAt := map[plumbing.Hash]bool{head.Hash(): true}
+	for _, b := range bases {
+		stopAt[b.Hash] = true
+	}
+
+	iter, err := v.repo.Log(&git.LogOptions{From: remoteRef.Hash()})
+	if err != nil {
+		return err
+	}
+
+	var bad string
+	err = iter.ForEach(func(c *object.Commit) error {
+		if stopAt[c.Hash] {
+			return storer.ErrStop
+		}
+		t := v.verifyCommit(c)
+		if t.Symbol != "✓" {
+			bad = fmt.Sprintf("%s (%s)", t.Hash[:10], t.Detail)
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if bad != "" {
+		return fmt.Errorf("refusing to sync: unsigned commit upstream: %s", bad)
+	}
+	return nil
+}
+
+// auth builds transport auth for the origin remote based on its URL scheme:
+// SSH agent auth for ssh/git@ remotes, or HTTP basic auth using a token from
+// MIND_GIT_TOKEN for https remotes. Returns nil if no auth is applicable.
+func (v *Vault) auth() (transport.AuthMethod, error) {
+	url, err := v.RemoteURL()
+	if err != nil {
+		return nil, nil
+	}
+
+	switch {
+	case strings.HasPrefix(url, "git@"), strings.HasPrefix(url, "ssh://"):
+		auth, err := ssh.NewSSHAgentAuth("git")
+		if err != nil {
+			return nil, fmt.Errorf("ssh agent auth: %w", err)
+		}
+		return auth, nil
+	case strings.HasPrefix(url, "https://"):
+		token := os.Getenv("MIND_GIT_TOKEN")
+		if token == "" {
+			return nil, nil
+		}
+		return &http.BasicAuth{Username: "mind", Password: token}, nil
+	default:
+		return nil, nil
+	}
+}
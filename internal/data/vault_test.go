@@ -0,0 +1,85 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVaultInitCommitStatusRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	v, initialized, err := Init(dir)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if !initialized {
+		t.Fatalf("expected a fresh directory to report initialized=true")
+	}
+
+	dirty, err := v.Dirty()
+	if err != nil {
+		t.Fatalf("Dirty: %v", err)
+	}
+	if dirty {
+		t.Errorf("expected a freshly initialized vault to be clean")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "p1.json"), []byte(`{"id":"p1","name":"P1","nodes":[]}`), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	dirty, err = v.Dirty()
+	if err != nil {
+		t.Fatalf("Dirty: %v", err)
+	}
+	if !dirty {
+		t.Errorf("expected an untracked file to make the vault dirty")
+	}
+
+	hash, committed, err := v.Commit("add p1")
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if !committed {
+		t.Fatalf("expected Commit to report committed=true for a dirty vault")
+	}
+	if hash == "" {
+		t.Errorf("expected Commit to return a non-empty hash")
+	}
+
+	dirty, err = v.Dirty()
+	if err != nil {
+		t.Fatalf("Dirty: %v", err)
+	}
+	if dirty {
+		t.Errorf("expected the vault to be clean right after committing")
+	}
+
+	_, committedAgain, err := v.Commit("nothing to commit")
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if committedAgain {
+		t.Errorf("expected Commit to be a no-op when there's nothing staged")
+	}
+}
+
+func TestInitOpensExistingVault(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, initialized, err := Init(dir); err != nil || !initialized {
+		t.Fatalf("Init: initialized=%v err=%v", initialized, err)
+	}
+
+	v, initialized, err := Init(dir)
+	if err != nil {
+		t.Fatalf("Init (reopen): %v", err)
+	}
+	if initialized {
+		t.Errorf("expected reopening an existing vault to report initialized=false")
+	}
+	if v == nil {
+		t.Fatalf("expected a non-nil Vault when reopening")
+	}
+}